@@ -0,0 +1,39 @@
+// Command modelgen generates typed Go model structs for every table in an
+// OVSDB schema file, for use with mapper.NewInfo.
+//
+// Usage:
+//
+//	modelgen -schema ovn-nb.ovsschema -dest ./pkg/nbmodel -package nbmodel
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ovn-org/libovsdb/pkg/modelgen"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "", "path to the OVSDB schema JSON file")
+	dest := flag.String("dest", ".", "destination directory for the generated files")
+	pkg := flag.String("package", "", "package name for the generated files")
+	flag.Parse()
+
+	if *schemaPath == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	schemaJSON, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "modelgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	gen := modelgen.NewGenerator(*pkg)
+	if err := gen.Generate(schemaJSON, *dest); err != nil {
+		fmt.Fprintf(os.Stderr, "modelgen: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,165 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+)
+
+// Codec bridges a Go type that cannot be described directly in terms of an
+// OVSDB column's native type (as returned by ovsdb.NativeType) to and from
+// the wire representation for that column. Codecs are consulted by NewInfo,
+// FieldByColumn and SetField whenever a struct field's type fails the
+// built-in schema-based type check.
+type Codec interface {
+	// Marshal converts the Go value in field to the native OVSDB value that
+	// should be sent over the wire for column.
+	Marshal(column *ovsdb.ColumnSchema, field reflect.Value) (interface{}, error)
+	// Unmarshal converts the native OVSDB value for column into field.
+	Unmarshal(column *ovsdb.ColumnSchema, value interface{}, field reflect.Value) error
+}
+
+// CodecRegistry holds Codecs keyed by the Go type they handle. A registry
+// can be attached to an individual Info (WithCodecRegistry) or used as the
+// package-wide default (DefaultCodecRegistry).
+type CodecRegistry struct {
+	codecs map[reflect.Type]Codec
+}
+
+// NewCodecRegistry returns an empty CodecRegistry.
+func NewCodecRegistry() *CodecRegistry {
+	return &CodecRegistry{codecs: make(map[reflect.Type]Codec)}
+}
+
+// Register associates codec with every value of typ's type. It overwrites
+// any codec previously registered for typ.
+func (r *CodecRegistry) Register(typ reflect.Type, codec Codec) {
+	r.codecs[typ] = codec
+}
+
+// CodecFor returns the codec registered for typ, if any.
+func (r *CodecRegistry) CodecFor(typ reflect.Type) (Codec, bool) {
+	if r == nil {
+		return nil, false
+	}
+	codec, ok := r.codecs[typ]
+	return codec, ok
+}
+
+// DefaultCodecRegistry is consulted by NewInfo when no per-Info registry has
+// been set via WithCodecRegistry. Built-in codecs for time.Time, net.IP,
+// net.HardwareAddr and json.RawMessage are registered here at init time.
+var DefaultCodecRegistry = NewCodecRegistry()
+
+func init() {
+	DefaultCodecRegistry.Register(reflect.TypeOf(time.Time{}), timeCodec{})
+	DefaultCodecRegistry.Register(reflect.TypeOf(net.IP{}), ipCodec{})
+	DefaultCodecRegistry.Register(reflect.TypeOf(net.HardwareAddr{}), hardwareAddrCodec{})
+	DefaultCodecRegistry.Register(reflect.TypeOf(json.RawMessage{}), rawMessageCodec{})
+}
+
+// timeCodec stores a time.Time as an OVSDB integer column holding Unix
+// seconds, the representation used by schemas such as OVN's NB/SB "_date"
+// style columns.
+type timeCodec struct{}
+
+func (timeCodec) Marshal(_ *ovsdb.ColumnSchema, field reflect.Value) (interface{}, error) {
+	t, ok := field.Interface().(time.Time)
+	if !ok {
+		return nil, fmt.Errorf("codec: expected time.Time, got %s", field.Type())
+	}
+	return t.Unix(), nil
+}
+
+func (timeCodec) Unmarshal(_ *ovsdb.ColumnSchema, value interface{}, field reflect.Value) error {
+	switch v := value.(type) {
+	case int:
+		field.Set(reflect.ValueOf(time.Unix(int64(v), 0).UTC()))
+	case int64:
+		field.Set(reflect.ValueOf(time.Unix(v, 0).UTC()))
+	case string:
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("codec: parsing time string %q: %w", v, err)
+		}
+		field.Set(reflect.ValueOf(time.Unix(seconds, 0).UTC()))
+	default:
+		return fmt.Errorf("codec: cannot unmarshal %T into time.Time", value)
+	}
+	return nil
+}
+
+// ipCodec stores a net.IP as an OVSDB string column.
+type ipCodec struct{}
+
+func (ipCodec) Marshal(_ *ovsdb.ColumnSchema, field reflect.Value) (interface{}, error) {
+	ip, ok := field.Interface().(net.IP)
+	if !ok {
+		return nil, fmt.Errorf("codec: expected net.IP, got %s", field.Type())
+	}
+	return ip.String(), nil
+}
+
+func (ipCodec) Unmarshal(_ *ovsdb.ColumnSchema, value interface{}, field reflect.Value) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("codec: cannot unmarshal %T into net.IP", value)
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return fmt.Errorf("codec: %q is not a valid IP address", s)
+	}
+	field.Set(reflect.ValueOf(ip))
+	return nil
+}
+
+// hardwareAddrCodec stores a net.HardwareAddr as an OVSDB string column.
+type hardwareAddrCodec struct{}
+
+func (hardwareAddrCodec) Marshal(_ *ovsdb.ColumnSchema, field reflect.Value) (interface{}, error) {
+	mac, ok := field.Interface().(net.HardwareAddr)
+	if !ok {
+		return nil, fmt.Errorf("codec: expected net.HardwareAddr, got %s", field.Type())
+	}
+	return mac.String(), nil
+}
+
+func (hardwareAddrCodec) Unmarshal(_ *ovsdb.ColumnSchema, value interface{}, field reflect.Value) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("codec: cannot unmarshal %T into net.HardwareAddr", value)
+	}
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		return fmt.Errorf("codec: %w", err)
+	}
+	field.Set(reflect.ValueOf(mac))
+	return nil
+}
+
+// rawMessageCodec stores a json.RawMessage as an OVSDB string column,
+// letting callers model a free-form JSON blob column without losing its
+// structure to a plain string field.
+type rawMessageCodec struct{}
+
+func (rawMessageCodec) Marshal(_ *ovsdb.ColumnSchema, field reflect.Value) (interface{}, error) {
+	raw, ok := field.Interface().(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("codec: expected json.RawMessage, got %s", field.Type())
+	}
+	return string(raw), nil
+}
+
+func (rawMessageCodec) Unmarshal(_ *ovsdb.ColumnSchema, value interface{}, field reflect.Value) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("codec: cannot unmarshal %T into json.RawMessage", value)
+	}
+	field.Set(reflect.ValueOf(json.RawMessage(s)))
+	return nil
+}
@@ -0,0 +1,186 @@
+package mapper
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeCodecRoundTrip(t *testing.T) {
+	c := timeCodec{}
+	want := time.Unix(1700000000, 0).UTC()
+
+	marshaled, err := c.Marshal(nil, reflect.ValueOf(want))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), marshaled)
+
+	var got time.Time
+	require.NoError(t, c.Unmarshal(nil, marshaled, reflect.ValueOf(&got).Elem()))
+	assert.True(t, want.Equal(got))
+
+	got = time.Time{}
+	require.NoError(t, c.Unmarshal(nil, "1700000000", reflect.ValueOf(&got).Elem()))
+	assert.True(t, want.Equal(got))
+
+	require.Error(t, c.Unmarshal(nil, "not-a-number", reflect.ValueOf(&got).Elem()))
+	require.Error(t, c.Unmarshal(nil, true, reflect.ValueOf(&got).Elem()))
+
+	_, err = c.Marshal(nil, reflect.ValueOf("not a time"))
+	require.Error(t, err)
+}
+
+func TestIPCodecRoundTrip(t *testing.T) {
+	c := ipCodec{}
+	want := net.ParseIP("192.0.2.1")
+
+	marshaled, err := c.Marshal(nil, reflect.ValueOf(want))
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", marshaled)
+
+	var got net.IP
+	require.NoError(t, c.Unmarshal(nil, marshaled, reflect.ValueOf(&got).Elem()))
+	assert.Equal(t, want, got)
+
+	require.Error(t, c.Unmarshal(nil, "not-an-ip", reflect.ValueOf(&got).Elem()))
+	require.Error(t, c.Unmarshal(nil, 1, reflect.ValueOf(&got).Elem()))
+
+	_, err = c.Marshal(nil, reflect.ValueOf("not an ip"))
+	require.Error(t, err)
+}
+
+func TestHardwareAddrCodecRoundTrip(t *testing.T) {
+	c := hardwareAddrCodec{}
+	want, err := net.ParseMAC("01:23:45:67:89:ab")
+	require.NoError(t, err)
+
+	marshaled, err := c.Marshal(nil, reflect.ValueOf(want))
+	require.NoError(t, err)
+	assert.Equal(t, "01:23:45:67:89:ab", marshaled)
+
+	var got net.HardwareAddr
+	require.NoError(t, c.Unmarshal(nil, marshaled, reflect.ValueOf(&got).Elem()))
+	assert.Equal(t, want, got)
+
+	require.Error(t, c.Unmarshal(nil, "not-a-mac", reflect.ValueOf(&got).Elem()))
+	require.Error(t, c.Unmarshal(nil, 1, reflect.ValueOf(&got).Elem()))
+
+	_, err = c.Marshal(nil, reflect.ValueOf("not a mac"))
+	require.Error(t, err)
+}
+
+func TestRawMessageCodecRoundTrip(t *testing.T) {
+	c := rawMessageCodec{}
+	want := json.RawMessage(`{"a":1}`)
+
+	marshaled, err := c.Marshal(nil, reflect.ValueOf(want))
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, marshaled)
+
+	var got json.RawMessage
+	require.NoError(t, c.Unmarshal(nil, marshaled, reflect.ValueOf(&got).Elem()))
+	assert.Equal(t, want, got)
+
+	require.Error(t, c.Unmarshal(nil, 1, reflect.ValueOf(&got).Elem()))
+
+	_, err = c.Marshal(nil, reflect.ValueOf("not raw json"))
+	require.Error(t, err)
+}
+
+func TestCodecRegistryRegisterAndCodecFor(t *testing.T) {
+	r := NewCodecRegistry()
+	typ := reflect.TypeOf(time.Time{})
+
+	_, ok := r.CodecFor(typ)
+	assert.False(t, ok)
+
+	r.Register(typ, timeCodec{})
+	codec, ok := r.CodecFor(typ)
+	require.True(t, ok)
+	assert.Equal(t, timeCodec{}, codec)
+
+	var nilRegistry *CodecRegistry
+	_, ok = nilRegistry.CodecFor(typ)
+	assert.False(t, ok)
+}
+
+func codecTable() *ovsdb.TableSchema {
+	return &ovsdb.TableSchema{
+		Columns: map[string]*ovsdb.ColumnSchema{
+			"created": {Type: "integer"},
+			"name":    {Type: "string"},
+		},
+	}
+}
+
+// TestNewInfoResolvesDefaultCodecs verifies NewInfo resolves a field whose
+// type (time.Time) fails the schema-based type check against DefaultCodecRegistry.
+func TestNewInfoResolvesDefaultCodecs(t *testing.T) {
+	type row struct {
+		Created time.Time `ovsdb:"created"`
+	}
+	table := codecTable()
+	obj := &row{}
+	info, err := NewInfo(table, obj)
+	require.NoError(t, err)
+
+	want := time.Unix(1700000000, 0).UTC()
+	require.NoError(t, info.SetField("created", int(1700000000)))
+	assert.True(t, want.Equal(obj.Created))
+
+	field, err := info.FieldByColumn("created")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), field)
+}
+
+// TestNewInfoUnresolvedCodecErr verifies NewInfo reports an ErrMapper when a
+// field's type fails the schema-based type check and no codec covers it.
+func TestNewInfoUnresolvedCodecErr(t *testing.T) {
+	type unregisteredType struct{ X int }
+	type row struct {
+		Name unregisteredType `ovsdb:"name"`
+	}
+	_, err := NewInfo(codecTable(), &row{})
+	require.Error(t, err)
+	var mapperErr *ErrMapper
+	require.ErrorAs(t, err, &mapperErr)
+}
+
+// TestNewInfoWithCodecRegistryOption verifies a per-Info registry overrides
+// DefaultCodecRegistry.
+func TestNewInfoWithCodecRegistryOption(t *testing.T) {
+	type unregisteredType struct{ X int }
+	type row struct {
+		Name unregisteredType `ovsdb:"name"`
+	}
+
+	registry := NewCodecRegistry()
+	registry.Register(reflect.TypeOf(unregisteredType{}), stubCodec{})
+
+	info, err := NewInfo(codecTable(), &row{}, WithCodecRegistry(registry))
+	require.NoError(t, err)
+
+	require.NoError(t, info.SetField("name", "7"))
+	assert.Equal(t, 7, info.obj.(*row).Name.X)
+}
+
+type stubCodec struct{}
+
+func (stubCodec) Marshal(_ *ovsdb.ColumnSchema, field reflect.Value) (interface{}, error) {
+	return field.Interface(), nil
+}
+
+func (stubCodec) Unmarshal(_ *ovsdb.ColumnSchema, value interface{}, field reflect.Value) error {
+	x, err := strconv.Atoi(value.(string))
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(struct{ X int }{X: x}))
+	return nil
+}
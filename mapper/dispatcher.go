@@ -0,0 +1,261 @@
+package mapper
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+)
+
+const instrumentationName = "github.com/ovn-org/libovsdb/mapper"
+
+// DeliveryMode controls how a Dispatcher hands row-change events to
+// registered Listeners.
+type DeliveryMode int
+
+const (
+	// DeliverySync calls every matching listener inline, before Dispatch
+	// returns. This is the simplest mode and preserves ordering trivially,
+	// but a slow listener blocks the whole dispatch call.
+	DeliverySync DeliveryMode = iota
+	// DeliveryAsync queues events onto an internal buffered channel drained
+	// by a single goroutine, so Dispatch does not wait for listeners; it
+	// blocks only while the buffer is full. Because a
+	// single goroutine drains the queue, events for every row are still
+	// delivered in the order they were dispatched.
+	DeliveryAsync
+)
+
+// ModelFactory returns a new, zero-valued pointer to the Go struct used to
+// model one table's rows, for the Dispatcher to populate from a Row.
+type ModelFactory func() interface{}
+
+// Dispatcher consumes raw OVSDB monitor update/update2 notifications,
+// materializes them into the typed Go structs registered via RegisterModel,
+// determines the set of changed columns for a modify from the columns the
+// server actually sent (RFC 7047 only includes a column in a modify's old
+// row if its value changed), and fans the result out to Listeners
+// registered via RegisterListener.
+type Dispatcher struct {
+	schema    *ovsdb.DatabaseSchema
+	models    map[string]ModelFactory
+	listeners map[string][]Listener // keyed by table, "" holds global listeners
+	registry  *CodecRegistry
+	mode      DeliveryMode
+
+	queue  chan func()
+	tracer trace.Tracer
+	events metric.Int64Counter
+}
+
+// NewDispatcher returns a Dispatcher that materializes rows against schema.
+// mode controls synchronous vs. buffered-async delivery; bufferSize is only
+// used in DeliveryAsync mode and must be >0 there.
+func NewDispatcher(schema *ovsdb.DatabaseSchema, mode DeliveryMode, bufferSize int) *Dispatcher {
+	meter := metric.Must(global.Meter(instrumentationName))
+	d := &Dispatcher{
+		schema:    schema,
+		models:    make(map[string]ModelFactory),
+		listeners: make(map[string][]Listener),
+		registry:  DefaultCodecRegistry,
+		mode:      mode,
+		tracer:    otel.Tracer(instrumentationName),
+		events:    meter.NewInt64Counter("libovsdb.mapper.dispatcher.events"),
+	}
+	if mode == DeliveryAsync {
+		d.queue = make(chan func(), bufferSize)
+		go d.drain()
+	}
+	return d
+}
+
+// RegisterModel associates table with the Go type factory used to
+// materialize its rows.
+func (d *Dispatcher) RegisterModel(table string, factory ModelFactory) {
+	d.models[table] = factory
+}
+
+// SetCodecRegistry overrides the CodecRegistry used when materializing rows.
+// Call before any events are dispatched.
+func (d *Dispatcher) SetCodecRegistry(registry *CodecRegistry) {
+	d.registry = registry
+}
+
+// RegisterListener subscribes l to changes for table. An empty table
+// registers l for every table the Dispatcher handles.
+func (d *Dispatcher) RegisterListener(table string, l Listener) {
+	d.listeners[table] = append(d.listeners[table], l)
+}
+
+// Dispatch processes a RFC 7047 "update" monitor notification.
+func (d *Dispatcher) Dispatch(ctx context.Context, updates ovsdb.TableUpdates) error {
+	ctx, span := d.tracer.Start(ctx, "Dispatcher.Dispatch")
+	defer span.End()
+	for table, tableUpdate := range updates {
+		for uuid, rowUpdate := range tableUpdate {
+			if err := d.dispatchRow(ctx, table, uuid, rowUpdate.Old, rowUpdate.New); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DispatchUpdate2 processes an "update2" monitor notification. A plain
+// modify is delivered as an Update event whose old object is nil and whose
+// new object is populated only from the modified columns; changed lists
+// those columns. Rows carrying Old or New are handled as in Dispatch.
+func (d *Dispatcher) DispatchUpdate2(ctx context.Context, updates ovsdb.TableUpdates2) error {
+	ctx, span := d.tracer.Start(ctx, "Dispatcher.DispatchUpdate2")
+	defer span.End()
+	for table, tableUpdate := range updates {
+		for uuid, rowUpdate := range tableUpdate {
+			oldRow, newRow := rowUpdate.Old, rowUpdate.New
+			if rowUpdate.Modify != nil && oldRow == nil && newRow == nil {
+				// update2's "modify" form carries only the changed columns;
+				// there's no prior value to diff against, so report the new
+				// columns as the changed set verbatim.
+				if err := d.dispatchModify(ctx, table, uuid, rowUpdate.Modify); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.dispatchRow(ctx, table, uuid, oldRow, newRow); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) dispatchRow(ctx context.Context, table, uuid string, oldRow, newRow *ovsdb.Row) error {
+	factory, ok := d.models[table]
+	if !ok {
+		// No model registered for this table: nothing to materialize or
+		// deliver, but not an error, since callers may monitor tables they
+		// have no listeners for.
+		return nil
+	}
+	tableSchema := d.schema.Table(table)
+	if tableSchema == nil {
+		return fmt.Errorf("mapper: table %s not found in schema", table)
+	}
+
+	var oldObj, newObj interface{}
+	var changed []string
+	var err error
+
+	// For a modify (oldRow and newRow both set), RFC 7047 puts only the
+	// columns that actually changed into oldRow, with their pre-modification
+	// values; newRow carries the full post-modification row. changed is
+	// therefore exactly oldRow's column set, not a value-level diff against
+	// newObj: oldObj only holds the columns the wire payload gave it, so
+	// comparing its zero-valued remainder against newObj would falsely
+	// flag every column the server omitted from oldRow as changed.
+	if oldRow != nil {
+		oldObj, changed, err = d.materialize(tableSchema, factory, *oldRow)
+		if err != nil {
+			return fmt.Errorf("mapper: materializing old %s row %s: %w", table, uuid, err)
+		}
+	}
+	if newRow != nil {
+		newObj, _, err = d.materialize(tableSchema, factory, *newRow)
+		if err != nil {
+			return fmt.Errorf("mapper: materializing new %s row %s: %w", table, uuid, err)
+		}
+	}
+	if oldObj == nil || newObj == nil {
+		// Not a modify: an insert or delete has nothing to report as changed.
+		changed = nil
+	}
+
+	d.events.Add(ctx, 1)
+	d.deliver(table, func() {
+		switch {
+		case oldObj == nil:
+			d.notify(table, func(l Listener) { l.OnInsert(table, newObj) })
+		case newObj == nil:
+			d.notify(table, func(l Listener) { l.OnDelete(table, oldObj) })
+		default:
+			d.notify(table, func(l Listener) { l.OnUpdate(table, oldObj, newObj, changed) })
+		}
+	})
+	return nil
+}
+
+func (d *Dispatcher) dispatchModify(ctx context.Context, table, uuid string, modify *ovsdb.Row) error {
+	factory, ok := d.models[table]
+	if !ok {
+		return nil
+	}
+	tableSchema := d.schema.Table(table)
+	if tableSchema == nil {
+		return fmt.Errorf("mapper: table %s not found in schema", table)
+	}
+	newObj, changed, err := d.materialize(tableSchema, factory, *modify)
+	if err != nil {
+		return fmt.Errorf("mapper: materializing %s modify %s: %w", table, uuid, err)
+	}
+
+	d.events.Add(ctx, 1)
+	d.deliver(table, func() {
+		d.notify(table, func(l Listener) { l.OnUpdate(table, nil, newObj, changed) })
+	})
+	return nil
+}
+
+// materialize populates a fresh model for table from row, returning the
+// object along with the schema columns row actually carried (ignoring any
+// wire columns the model doesn't map), so callers needing to know which
+// columns a partial row touched don't have to re-walk it themselves.
+func (d *Dispatcher) materialize(table *ovsdb.TableSchema, factory ModelFactory, row ovsdb.Row) (interface{}, []string, error) {
+	obj := factory()
+	info, err := NewInfo(table, obj, WithCodecRegistry(d.registry))
+	if err != nil {
+		return nil, nil, err
+	}
+	var set []string
+	for column, value := range row {
+		if !info.hasColumn(column) {
+			continue
+		}
+		if err := info.SetField(column, value); err != nil {
+			return nil, nil, err
+		}
+		set = append(set, column)
+	}
+	return obj, set, nil
+}
+
+// deliver runs fn according to the Dispatcher's DeliveryMode.
+func (d *Dispatcher) deliver(table string, fn func()) {
+	if d.mode == DeliverySync {
+		fn()
+		return
+	}
+	d.queue <- fn
+}
+
+func (d *Dispatcher) drain() {
+	for fn := range d.queue {
+		fn()
+	}
+}
+
+// notify calls fn for every listener registered for table plus every
+// listener registered globally (table "").
+func (d *Dispatcher) notify(table string, fn func(Listener)) {
+	for _, l := range d.listeners[table] {
+		fn(l)
+	}
+	if table != "" {
+		for _, l := range d.listeners[""] {
+			fn(l)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package mapper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dispatchRow struct {
+	UUID string `ovsdb:"_uuid"`
+	Name string `ovsdb:"name"`
+	Up   bool   `ovsdb:"up"`
+}
+
+func dispatchSchema() *ovsdb.DatabaseSchema {
+	return &ovsdb.DatabaseSchema{
+		Name: "test",
+		Tables: map[string]ovsdb.TableSchema{
+			"T": {
+				Columns: map[string]*ovsdb.ColumnSchema{
+					"_uuid": {Type: "uuid"},
+					"name":  {Type: "string"},
+					"up":    {Type: "boolean"},
+				},
+			},
+		},
+	}
+}
+
+type recordingListener struct {
+	inserts []interface{}
+	updates []interface{}
+	deletes []interface{}
+	changed [][]string
+}
+
+func (l *recordingListener) OnInsert(table string, row interface{}) {
+	l.inserts = append(l.inserts, row)
+}
+
+func (l *recordingListener) OnUpdate(table string, old, new interface{}, changed []string) {
+	l.updates = append(l.updates, new)
+	l.changed = append(l.changed, changed)
+}
+
+func (l *recordingListener) OnDelete(table string, row interface{}) {
+	l.deletes = append(l.deletes, row)
+}
+
+// TestDispatcherDispatchInsertUpdateDelete exercises the legacy "update"
+// notification path. The modify's old row is realistically partial, as an
+// OVSDB server actually sends it: only the columns that changed, with their
+// pre-modification values. up is carried in new but didn't change, so it
+// must not appear in changed even though it's absent from old.
+func TestDispatcherDispatchInsertUpdateDelete(t *testing.T) {
+	d := NewDispatcher(dispatchSchema(), DeliverySync, 0)
+	d.RegisterModel("T", func() interface{} { return &dispatchRow{} })
+	l := &recordingListener{}
+	d.RegisterListener("T", l)
+
+	insert := ovsdb.Row{"_uuid": "1", "name": "sw0", "up": true}
+	require.NoError(t, d.Dispatch(context.Background(), ovsdb.TableUpdates{
+		"T": {"1": {Old: nil, New: &insert}},
+	}))
+	require.Len(t, l.inserts, 1)
+	assert.Equal(t, "sw0", l.inserts[0].(*dispatchRow).Name)
+
+	oldRow := ovsdb.Row{"name": "sw0"}
+	newRow := ovsdb.Row{"_uuid": "1", "name": "sw1", "up": true}
+	require.NoError(t, d.Dispatch(context.Background(), ovsdb.TableUpdates{
+		"T": {"1": {Old: &oldRow, New: &newRow}},
+	}))
+	require.Len(t, l.updates, 1)
+	assert.Equal(t, "sw1", l.updates[0].(*dispatchRow).Name)
+	assert.Equal(t, []string{"name"}, l.changed[0])
+
+	delRow := ovsdb.Row{"_uuid": "1", "name": "sw1", "up": true}
+	require.NoError(t, d.Dispatch(context.Background(), ovsdb.TableUpdates{
+		"T": {"1": {Old: &delRow, New: nil}},
+	}))
+	require.Len(t, l.deletes, 1)
+	assert.Equal(t, "sw1", l.deletes[0].(*dispatchRow).Name)
+}
+
+func TestDispatcherIgnoresUnregisteredTable(t *testing.T) {
+	d := NewDispatcher(dispatchSchema(), DeliverySync, 0)
+	l := &recordingListener{}
+	d.RegisterListener("", l)
+
+	row := ovsdb.Row{"_uuid": "1", "name": "sw0"}
+	require.NoError(t, d.Dispatch(context.Background(), ovsdb.TableUpdates{
+		"T": {"1": {Old: nil, New: &row}},
+	}))
+	assert.Empty(t, l.inserts)
+}
+
+// TestDispatcherDispatchUpdate2Modify exercises the update2 "modify" form,
+// whose Row carries only the columns that changed with their post-modify
+// values and no separate old/new split.
+func TestDispatcherDispatchUpdate2Modify(t *testing.T) {
+	d := NewDispatcher(dispatchSchema(), DeliverySync, 0)
+	d.RegisterModel("T", func() interface{} { return &dispatchRow{} })
+	l := &recordingListener{}
+	d.RegisterListener("T", l)
+
+	modify := ovsdb.Row{"name": "sw1"}
+	require.NoError(t, d.DispatchUpdate2(context.Background(), ovsdb.TableUpdates2{
+		"T": {"1": {Modify: &modify}},
+	}))
+	require.Len(t, l.updates, 1)
+	assert.Equal(t, "sw1", l.updates[0].(*dispatchRow).Name)
+	assert.Equal(t, []string{"name"}, l.changed[0])
+}
+
+// TestDispatcherDeliveryAsync verifies that DeliveryAsync mode still
+// delivers events, via its background drain goroutine, in dispatch order.
+func TestDispatcherDeliveryAsync(t *testing.T) {
+	d := NewDispatcher(dispatchSchema(), DeliveryAsync, 4)
+	d.RegisterModel("T", func() interface{} { return &dispatchRow{} })
+	l := &recordingListener{}
+	d.RegisterListener("T", l)
+
+	for i, name := range []string{"sw0", "sw1", "sw2"} {
+		row := ovsdb.Row{"_uuid": "1", "name": name}
+		require.NoError(t, d.Dispatch(context.Background(), ovsdb.TableUpdates{
+			"T": {"1": {Old: nil, New: &row}},
+		}))
+		_ = i
+	}
+
+	require.Eventually(t, func() bool {
+		return len(l.inserts) == 3
+	}, time.Second, time.Millisecond, "listener did not receive all async events")
+	assert.Equal(t, "sw0", l.inserts[0].(*dispatchRow).Name)
+	assert.Equal(t, "sw1", l.inserts[1].(*dispatchRow).Name)
+	assert.Equal(t, "sw2", l.inserts[2].(*dispatchRow).Name)
+}
@@ -10,34 +10,103 @@ import (
 // Info is a struct that handles the type map of an object
 // The object must have exported tagged fields with the 'ovs'
 type Info struct {
-	// FieldName indexed by column
-	fields map[string]string
-	obj    interface{}
-	table  *ovsdb.TableSchema
+	// plan is the compiled, cached field layout for obj's type against table.
+	plan *compiledPlan
+	// codecs holds the Codec to use for a direct column, for columns whose
+	// field type is not natively representable and was matched against
+	// registry instead of the schema-based type check.
+	codecs map[string]Codec
+	// mapCodecs holds the Codec to use for one dotted "column.key" field,
+	// indexed the same way: by column, then by the map key the field
+	// addresses.
+	mapCodecs map[string]map[string]Codec
+	registry  *CodecRegistry
+	obj       interface{}
+	table     *ovsdb.TableSchema
+}
+
+// InfoOption configures an Info returned by NewInfo.
+type InfoOption func(*Info)
+
+// WithCodecRegistry attaches a CodecRegistry to use instead of
+// DefaultCodecRegistry when resolving fields whose type doesn't natively
+// match their column's schema type.
+func WithCodecRegistry(registry *CodecRegistry) InfoOption {
+	return func(i *Info) {
+		i.registry = registry
+	}
 }
 
 // FieldByColumn returns the field value that corresponds to a column
 func (i *Info) FieldByColumn(column string) (interface{}, error) {
-	fieldName, ok := i.fields[column]
+	cp, ok := i.plan.byColumn[column]
 	if !ok {
-		return nil, fmt.Errorf("column %s not found in orm info", column)
+		return nil, i.plan.fieldNotFoundError(column)
 	}
-	return reflect.ValueOf(i.obj).Elem().FieldByName(fieldName).Interface(), nil
+	if cp.direct != nil {
+		fieldValue := reflect.ValueOf(i.obj).Elem().FieldByIndex(cp.direct.index)
+		if codec, ok := i.codecs[column]; ok {
+			return codec.Marshal(i.table.Column(column), fieldValue)
+		}
+		return fieldValue.Interface(), nil
+	}
+	return i.mapFieldByColumn(column, cp)
 }
 
-// FieldByColumn returns the field value that corresponds to a column
+// mapFieldByColumn reassembles the map value for column out of every
+// dotted-tag field addressing one of its well-known keys.
+func (i *Info) mapFieldByColumn(column string, cp *columnPlan) (interface{}, error) {
+	schema := i.table.Column(column)
+	mapType := ovsdb.NativeType(schema)
+	result := reflect.MakeMapWithSize(mapType, len(cp.mapFields))
+
+	for key, cf := range cp.mapFields {
+		fieldValue := reflect.ValueOf(i.obj).Elem().FieldByIndex(cf.index)
+		if fieldValue.IsZero() {
+			// Unset mixin fields don't contribute an entry to the map.
+			continue
+		}
+
+		val := fieldValue
+		if codec, ok := i.mapCodecs[column][key]; ok {
+			marshaled, err := codec.Marshal(schema, fieldValue)
+			if err != nil {
+				return nil, err
+			}
+			val = reflect.ValueOf(marshaled)
+		}
+		if !val.Type().AssignableTo(mapType.Elem()) {
+			if !val.Type().ConvertibleTo(mapType.Elem()) {
+				return nil, fmt.Errorf("column %s: value for key %q (%s) is not assignable or convertible to %s", column, key, val.Type(), mapType.Elem())
+			}
+			val = val.Convert(mapType.Elem())
+		}
+		result.SetMapIndex(reflect.ValueOf(key).Convert(mapType.Key()), val)
+	}
+	return result.Interface(), nil
+}
+
+// hasColumn returns whether column is mapped to a field of the bound object
 func (i *Info) hasColumn(column string) bool {
-	_, ok := i.fields[column]
+	_, ok := i.plan.byColumn[column]
 	return ok
 }
 
 // SetField sets the field in the column to the specified value
 func (i *Info) SetField(column string, value interface{}) error {
-	fieldName, ok := i.fields[column]
+	cp, ok := i.plan.byColumn[column]
 	if !ok {
-		return fmt.Errorf("column %s not found in orm info", column)
+		return i.plan.fieldNotFoundError(column)
+	}
+	if cp.direct == nil {
+		return i.setMapField(column, value, cp)
+	}
+
+	cf := cp.direct
+	fieldValue := reflect.ValueOf(i.obj).Elem().FieldByIndex(cf.index)
+	if codec, ok := i.codecs[column]; ok {
+		return codec.Unmarshal(i.table.Column(column), value, fieldValue)
 	}
-	fieldValue := reflect.ValueOf(i.obj).Elem().FieldByName(fieldName)
 	v := reflect.ValueOf(value)
 	if !fieldValue.Type().AssignableTo(reflect.TypeOf(value)) {
 		if fieldValue.Kind() == reflect.Ptr {
@@ -59,7 +128,7 @@ func (i *Info) SetField(column string, value interface{}) error {
 			// handle set of enums
 			if !v.Type().Elem().ConvertibleTo(fieldValue.Type().Elem()) {
 				return fmt.Errorf("column %s: element %v (%s) is not convertible to field %s element (%s)",
-					column, value, reflect.TypeOf(value), fieldName, fieldValue.Type())
+					column, value, reflect.TypeOf(value), cf.column, fieldValue.Type())
 			}
 			nv := reflect.Zero(fieldValue.Type())
 			for i := 0; i < v.Len(); i++ {
@@ -68,13 +137,45 @@ func (i *Info) SetField(column string, value interface{}) error {
 			v = nv
 		} else {
 			return fmt.Errorf("column %s: native value %v (%s) is not assignable or convertible to field %s (%s)",
-				column, value, reflect.TypeOf(value), fieldName, fieldValue.Type())
+				column, value, reflect.TypeOf(value), cf.column, fieldValue.Type())
 		}
 	}
 	fieldValue.Set(v)
 	return nil
 }
 
+// setMapField distributes value, the native map for column, across every
+// dotted-tag field addressing one of its well-known keys. Keys value
+// doesn't contain are left at their Go zero value.
+func (i *Info) setMapField(column string, value interface{}, cp *columnPlan) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map {
+		return fmt.Errorf("column %s: native value %v (%s) is not a map", column, value, reflect.TypeOf(value))
+	}
+	schema := i.table.Column(column)
+	for key, cf := range cp.mapFields {
+		mapVal := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if !mapVal.IsValid() {
+			continue
+		}
+		fieldValue := reflect.ValueOf(i.obj).Elem().FieldByIndex(cf.index)
+		if codec, ok := i.mapCodecs[column][key]; ok {
+			if err := codec.Unmarshal(schema, mapVal.Interface(), fieldValue); err != nil {
+				return err
+			}
+			continue
+		}
+		if mapVal.Type().AssignableTo(fieldValue.Type()) {
+			fieldValue.Set(mapVal)
+		} else if mapVal.Type().ConvertibleTo(fieldValue.Type()) {
+			fieldValue.Set(mapVal.Convert(fieldValue.Type()))
+		} else {
+			return fmt.Errorf("column %s: value for key %q (%s) is not assignable or convertible to field (%s)", column, key, mapVal.Type(), fieldValue.Type())
+		}
+	}
+	return nil
+}
+
 // ColumnByPtr returns the column name that corresponds to the field by the field's pointer
 func (i *Info) ColumnByPtr(fieldPtr interface{}) (string, error) {
 	fieldPtrVal := reflect.ValueOf(fieldPtr)
@@ -82,36 +183,26 @@ func (i *Info) ColumnByPtr(fieldPtr interface{}) (string, error) {
 		return "", ovsdb.NewErrWrongType("ColumnByPointer", "pointer to a field in the struct", fieldPtr)
 	}
 	offset := fieldPtrVal.Pointer() - reflect.ValueOf(i.obj).Pointer()
-	objType := reflect.TypeOf(i.obj).Elem()
-	for j := 0; j < objType.NumField(); j++ {
-		if objType.Field(j).Offset == offset {
-			column := objType.Field(j).Tag.Get("ovsdb")
-			if _, ok := i.fields[column]; !ok {
-				return "", fmt.Errorf("field does not have orm column information")
-			}
-			return column, nil
-		}
+	cf, ok := i.plan.byOffset[offset]
+	if !ok {
+		return "", fmt.Errorf("field pointer does not correspond to orm struct")
 	}
-	return "", fmt.Errorf("field pointer does not correspond to orm struct")
+	return cf.column, nil
 }
 
 // getValidIndexes inspects the object and returns the a list of indexes (set of columns) for witch
 // the object has non-default values
 func (i *Info) getValidIndexes() ([][]string, error) {
 	var validIndexes [][]string
-	var possibleIndexes [][]string
-
-	possibleIndexes = append(possibleIndexes, []string{"_uuid"})
-	possibleIndexes = append(possibleIndexes, i.table.Indexes...)
 
 	// Iterate through indexes and validate them
 OUTER:
-	for _, idx := range possibleIndexes {
-		for _, col := range idx {
+	for n, idx := range i.plan.possibleIndexes {
+		for j, col := range idx {
 			if !i.hasColumn(col) {
 				continue OUTER
 			}
-			columnSchema := i.table.Column(col)
+			columnSchema := i.plan.indexSchemas[n][j]
 			if columnSchema == nil {
 				continue OUTER
 			}
@@ -128,8 +219,15 @@ OUTER:
 	return validIndexes, nil
 }
 
+// Indexes returns the list of schema indexes (including "_uuid") for which the
+// bound object currently holds non-default values. Generated model code uses
+// this to build Get/List queries without duplicating index validation logic.
+func (i *Info) Indexes() ([][]string, error) {
+	return i.getValidIndexes()
+}
+
 // NewInfo creates a MapperInfo structure around an object based on a given table schema
-func NewInfo(table *ovsdb.TableSchema, obj interface{}) (*Info, error) {
+func NewInfo(table *ovsdb.TableSchema, obj interface{}, opts ...InfoOption) (*Info, error) {
 	objPtrVal := reflect.ValueOf(obj)
 	if objPtrVal.Type().Kind() != reflect.Ptr {
 		return nil, ovsdb.NewErrWrongType("NewMapperInfo", "pointer to a struct", obj)
@@ -138,51 +236,56 @@ func NewInfo(table *ovsdb.TableSchema, obj interface{}) (*Info, error) {
 	if objVal.Kind() != reflect.Struct {
 		return nil, ovsdb.NewErrWrongType("NewMapperInfo", "pointer to a struct", obj)
 	}
-	objType := objVal.Type()
 
-	fields := make(map[string]string, objType.NumField())
-	for i := 0; i < objType.NumField(); i++ {
-		field := objType.Field(i)
-		colName := field.Tag.Get("ovsdb")
-		if colName == "" {
-			// Untagged fields are ignored
-			continue
-		}
-		column := table.Column(colName)
-		if column == nil {
-			return nil, &ErrMapper{
-				objType:   objType.String(),
-				field:     field.Name,
-				fieldType: field.Type.String(),
-				fieldTag:  colName,
-				reason:    "Column does not exist in schema",
+	plan, err := planFor(objVal.Type(), table)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{obj: obj, table: table, plan: plan}
+	for _, opt := range opts {
+		opt(info)
+	}
+	if info.registry == nil {
+		info.registry = DefaultCodecRegistry
+	}
+
+	codecs := make(map[string]Codec)
+	mapCodecs := make(map[string]map[string]Codec)
+	for column, cp := range plan.byColumn {
+		if cp.direct != nil && cp.direct.needsCodec {
+			codec, ok := info.registry.CodecFor(cp.direct.fieldType)
+			if !ok {
+				return nil, unresolvedCodecErr(objVal, column, cp.direct, table)
 			}
+			codecs[column] = codec
 		}
-
-		// Perform schema-based type checking
-		expType := ovsdb.NativeType(column)
-		// check for slice of enums
-		if expType.Kind() == reflect.Slice && expType.Elem().Kind() == reflect.String {
-			// it's a slice of enums
-		} else if expType.Kind() == reflect.String && field.Type.Kind() == reflect.String {
-			// it's an enum
-		} else if expType.Kind() == reflect.Ptr && expType.Elem().Kind() == reflect.String && field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.String {
-			// it's a pointer to an enum
-		} else if expType != field.Type {
-			return nil, &ErrMapper{
-				objType:   objType.String(),
-				field:     field.Name,
-				fieldType: field.Type.String(),
-				fieldTag:  colName,
-				reason:    fmt.Sprintf("Wrong type, column expects %s", expType),
+		for key, cf := range cp.mapFields {
+			if !cf.needsCodec {
+				continue
+			}
+			codec, ok := info.registry.CodecFor(cf.fieldType)
+			if !ok {
+				return nil, unresolvedCodecErr(objVal, column+"."+key, cf, table)
+			}
+			if mapCodecs[column] == nil {
+				mapCodecs[column] = make(map[string]Codec)
 			}
+			mapCodecs[column][key] = codec
 		}
-		fields[colName] = field.Name
 	}
+	info.codecs = codecs
+	info.mapCodecs = mapCodecs
 
-	return &Info{
-		fields: fields,
-		obj:    obj,
-		table:  table,
-	}, nil
+	return info, nil
+}
+
+func unresolvedCodecErr(objVal reflect.Value, tag string, cf *compiledField, table *ovsdb.TableSchema) error {
+	return &ErrMapper{
+		objType:   objVal.Type().String(),
+		field:     cf.column,
+		fieldType: cf.fieldType.String(),
+		fieldTag:  tag,
+		reason:    fmt.Sprintf("Wrong type, column expects %s", ovsdb.NativeType(table.Column(cf.column))),
+	}
 }
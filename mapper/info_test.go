@@ -0,0 +1,89 @@
+package mapper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mixinTableSchema = []byte(`{
+	"columns": {
+		"name": {
+			"type": "string"
+		},
+		"external_ids": {
+			"type": {
+				"key": "string",
+				"value": "string"
+			}
+		}
+	}
+}`)
+
+// Metadata is a mixin embedded by value across several table structs,
+// exercising anonymous struct field promotion.
+type Metadata struct {
+	ExternalIDFoo string `ovsdb:"external_ids.foo"`
+	ExternalIDBar string `ovsdb:"external_ids.bar"`
+}
+
+type mixinRow struct {
+	Metadata
+	Name string `ovsdb:"name"`
+}
+
+func mixinTable(t *testing.T) *ovsdb.TableSchema {
+	t.Helper()
+	var table ovsdb.TableSchema
+	require.NoError(t, json.Unmarshal(mixinTableSchema, &table))
+	return &table
+}
+
+func TestNewInfoEmbeddedStruct(t *testing.T) {
+	table := mixinTable(t)
+	obj := &mixinRow{Name: "sw0"}
+	info, err := NewInfo(table, obj)
+	require.NoError(t, err)
+
+	assert.True(t, info.hasColumn("name"))
+	assert.True(t, info.hasColumn("external_ids"))
+
+	require.NoError(t, info.SetField("name", "sw1"))
+	assert.Equal(t, "sw1", obj.Name)
+
+	column, err := info.ColumnByPtr(&obj.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "name", column)
+}
+
+func TestDottedMapKeyTagRoundTrip(t *testing.T) {
+	table := mixinTable(t)
+	obj := &mixinRow{Name: "sw0"}
+	info, err := NewInfo(table, obj)
+	require.NoError(t, err)
+
+	require.NoError(t, info.SetField("external_ids", map[string]string{"foo": "bar", "other": "ignored"}))
+	assert.Equal(t, "bar", obj.ExternalIDFoo)
+	assert.Equal(t, "", obj.ExternalIDBar)
+
+	// ColumnByPtr must report the real schema column, not the dotted tag.
+	column, err := info.ColumnByPtr(&obj.ExternalIDFoo)
+	require.NoError(t, err)
+	assert.Equal(t, "external_ids", column)
+
+	field, err := info.FieldByColumn("external_ids")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"foo": "bar"}, field)
+}
+
+func TestDottedMapKeyTagRejectsNonMapColumn(t *testing.T) {
+	table := mixinTable(t)
+	type badRow struct {
+		Name string `ovsdb:"name.foo"`
+	}
+	_, err := NewInfo(table, &badRow{})
+	require.Error(t, err)
+}
@@ -0,0 +1,19 @@
+package mapper
+
+// Listener receives typed row-change notifications for a table, materialized
+// from raw OVSDB monitor update/update2 notifications by a Dispatcher.
+//
+// A Dispatcher materializes a fresh object for every row in both delivery
+// modes, so implementations may freely retain row, old, and new beyond the
+// call without risk of them being mutated afterward.
+type Listener interface {
+	// OnInsert is called when a new row appears in table.
+	OnInsert(table string, row interface{})
+	// OnUpdate is called when a row in table changes. changed holds the
+	// OVSDB column names whose value differs between old and new. For an
+	// update2 "modify", old is nil, new holds only the modified columns and
+	// changed lists those columns.
+	OnUpdate(table string, old, new interface{}, changed []string)
+	// OnDelete is called when a row is removed from table.
+	OnDelete(table string, row interface{})
+}
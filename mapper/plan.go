@@ -0,0 +1,234 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+)
+
+// compiledField is the result of resolving a single tagged struct field
+// against a table schema once, so later lookups never need to walk the
+// struct's fields again.
+type compiledField struct {
+	// column is the real schema column this field addresses, even for a
+	// dotted tag (e.g. "external_ids.foo" resolves column to "external_ids").
+	column string
+	// index is the FieldByIndex path to the field, relative to the struct.
+	index []int
+	// offset is the field's memory offset within the struct, used to
+	// resolve ColumnByPtr without a linear scan.
+	offset    uintptr
+	fieldType reflect.Type
+	// needsCodec is true when the field's type didn't satisfy the
+	// schema-based type check and a Codec must bridge it instead.
+	needsCodec bool
+}
+
+// columnPlan is the resolved mapping for a single schema column. A column
+// tagged directly on a field (e.g. `ovsdb:"name"`) populates direct; a
+// column addressed piecewise by one or more dotted map-key tags (e.g.
+// `ovsdb:"external_ids.foo"`) populates mapFields instead, keyed by the map
+// key each field addresses. A column is never both.
+type columnPlan struct {
+	column    string
+	direct    *compiledField
+	mapFields map[string]*compiledField
+}
+
+// compiledPlan is the reusable, schema- and type-bound part of an Info: the
+// set of tagged fields on a given Go type matched against a given table
+// schema. Plans are cached in planCache and shared by every Info created
+// for the same Go type and table schema, the schema being identified by
+// its Columns map (see planKey).
+type compiledPlan struct {
+	byColumn map[string]*columnPlan
+	byOffset map[uintptr]*compiledField
+
+	// possibleIndexes is "_uuid" followed by table.Indexes, with the
+	// ColumnSchema for each member resolved up front so getValidIndexes
+	// doesn't repeat table.Column lookups on every call.
+	possibleIndexes [][]string
+	indexSchemas    [][]*ovsdb.ColumnSchema
+}
+
+type planKey struct {
+	objType reflect.Type
+	// columns identifies the table schema by the address of its Columns
+	// map rather than by *ovsdb.TableSchema. DatabaseSchema.Table returns
+	// a fresh *TableSchema on every call (it indexes a map[string]TableSchema
+	// by value), so a pointer-keyed cache would never hit on the dispatcher's
+	// per-row lookup path. The Columns map itself, however, is copied by
+	// reference every time, so its address is stable for the schema's
+	// lifetime and safe to use as the real cache key.
+	//
+	// This is deliberately an unsafe.Pointer, not a uintptr: storing it in
+	// planCache keeps the underlying Columns map reachable for as long as
+	// the cache entry lives (planCache is never evicted), so its address
+	// can never be reused by an unrelated map while a stale entry for it
+	// remains cached. A uintptr gives the GC no reason to keep the map
+	// alive, so a later, unrelated map could legally be allocated at the
+	// same address once the original was collected.
+	columns unsafe.Pointer
+}
+
+// planCacheKey computes the planKey identifying objType against table.
+func planCacheKey(objType reflect.Type, table *ovsdb.TableSchema) planKey {
+	return planKey{objType: objType, columns: reflect.ValueOf(table.Columns).UnsafePointer()}
+}
+
+// planCache holds compiledPlans keyed by planKey. It is safe for concurrent
+// use, which matters because Transact and monitor update handling map many
+// rows concurrently.
+var planCache sync.Map // map[planKey]*compiledPlan
+
+func compilePlan(objType reflect.Type, table *ovsdb.TableSchema) (*compiledPlan, error) {
+	plan := &compiledPlan{
+		byColumn: make(map[string]*columnPlan, objType.NumField()),
+		byOffset: make(map[uintptr]*compiledField, objType.NumField()),
+	}
+
+	if err := walkFields(objType, nil, 0, table, plan); err != nil {
+		return nil, err
+	}
+
+	plan.possibleIndexes = append(plan.possibleIndexes, []string{"_uuid"})
+	plan.possibleIndexes = append(plan.possibleIndexes, table.Indexes...)
+	plan.indexSchemas = make([][]*ovsdb.ColumnSchema, len(plan.possibleIndexes))
+	for i, idx := range plan.possibleIndexes {
+		schemas := make([]*ovsdb.ColumnSchema, len(idx))
+		for j, col := range idx {
+			schemas[j] = table.Column(col)
+		}
+		plan.indexSchemas[i] = schemas
+	}
+
+	return plan, nil
+}
+
+// walkFields recurses through objType's fields, promoting the ovsdb-tagged
+// fields of any anonymous (embedded) struct field into plan.byColumn as if
+// they were declared directly on objType. prefix is the FieldByIndex path
+// to objType itself (nil at the top level) and baseOffset is objType's own
+// offset within the outermost struct, so that embedded fields resolve to
+// the same flat memory offset ColumnByPtr compares against.
+func walkFields(objType reflect.Type, prefix []int, baseOffset uintptr, table *ovsdb.TableSchema, plan *compiledPlan) error {
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		index := append(append([]int{}, prefix...), i)
+		colName := field.Tag.Get("ovsdb")
+
+		if colName == "" {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				if err := walkFields(field.Type, index, baseOffset+field.Offset, table, plan); err != nil {
+					return err
+				}
+			}
+			// Other untagged fields are ignored
+			continue
+		}
+
+		baseColumn, mapKey, cf, err := resolveField(objType, field, colName, table)
+		if err != nil {
+			return err
+		}
+		cf.index = index
+		cf.offset = baseOffset + field.Offset
+		plan.byOffset[cf.offset] = cf
+
+		cp, ok := plan.byColumn[baseColumn]
+		if !ok {
+			cp = &columnPlan{column: baseColumn}
+			plan.byColumn[baseColumn] = cp
+		}
+		if mapKey == "" {
+			cp.direct = cf
+		} else {
+			if cp.mapFields == nil {
+				cp.mapFields = make(map[string]*compiledField)
+			}
+			cp.mapFields[mapKey] = cf
+		}
+	}
+	return nil
+}
+
+// resolveField validates a single tagged field against the schema, handling
+// both plain column tags (e.g. "name") and dotted tags (e.g.
+// "external_ids.foo") that address one well-known key of a map column. It
+// returns the real schema column the field addresses (baseColumn) and, for
+// a dotted tag, the map key within that column (mapKey).
+func resolveField(objType reflect.Type, field reflect.StructField, colName string, table *ovsdb.TableSchema) (string, string, *compiledField, error) {
+	baseColumn := colName
+	mapKey := ""
+	if dot := strings.Index(colName, "."); dot >= 0 {
+		baseColumn, mapKey = colName[:dot], colName[dot+1:]
+	}
+
+	column := table.Column(baseColumn)
+	if column == nil {
+		return "", "", nil, &ErrMapper{
+			objType:   objType.String(),
+			field:     field.Name,
+			fieldType: field.Type.String(),
+			fieldTag:  colName,
+			reason:    "Column does not exist in schema",
+		}
+	}
+
+	expType := ovsdb.NativeType(column)
+	if mapKey != "" {
+		if expType.Kind() != reflect.Map {
+			return "", "", nil, &ErrMapper{
+				objType:   objType.String(),
+				field:     field.Name,
+				fieldType: field.Type.String(),
+				fieldTag:  colName,
+				reason:    fmt.Sprintf("column %s is not a map column, cannot address key %q", baseColumn, mapKey),
+			}
+		}
+		expType = expType.Elem()
+	}
+
+	needsCodec := false
+	// check for slice of enums
+	if expType.Kind() == reflect.Slice && expType.Elem().Kind() == reflect.String {
+		// it's a slice of enums
+	} else if expType.Kind() == reflect.String && field.Type.Kind() == reflect.String {
+		// it's an enum
+	} else if expType.Kind() == reflect.Ptr && expType.Elem().Kind() == reflect.String && field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.String {
+		// it's a pointer to an enum
+	} else if expType != field.Type {
+		// Resolved against the registry later, per-Info, since the
+		// registry is not part of the cache key.
+		needsCodec = true
+	}
+
+	return baseColumn, mapKey, &compiledField{
+		column:     baseColumn,
+		fieldType:  field.Type,
+		needsCodec: needsCodec,
+	}, nil
+}
+
+// planFor returns the compiledPlan for objType against table, computing and
+// caching it on the first call for that pair.
+func planFor(objType reflect.Type, table *ovsdb.TableSchema) (*compiledPlan, error) {
+	key := planCacheKey(objType, table)
+	if cached, ok := planCache.Load(key); ok {
+		return cached.(*compiledPlan), nil
+	}
+	plan, err := compilePlan(objType, table)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := planCache.LoadOrStore(key, plan)
+	return actual.(*compiledPlan), nil
+}
+
+func (p *compiledPlan) fieldNotFoundError(column string) error {
+	return fmt.Errorf("column %s not found in orm info", column)
+}
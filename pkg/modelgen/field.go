@@ -0,0 +1,192 @@
+package modelgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+)
+
+// FieldInfo describes a single Go struct field generated for an OVSDB column
+type FieldInfo struct {
+	// Column is the OVSDB column name (e.g. "external_ids")
+	Column string
+	// FieldName is the exported Go field name (e.g. "ExternalIDS")
+	FieldName string
+	// Type is the Go type used for the field, rendered as source (e.g. "map[string]string")
+	Type string
+	// Tag is the full struct tag emitted for the field
+	Tag string
+	// Comment, if set, is emitted as a doc comment above the field
+	Comment string
+	// EnumType, if non-empty, is the name of a generated named type for an
+	// enum column (e.g. "LogicalSwitchPortType")
+	EnumType string
+	// EnumValues holds the schema-declared values of an enum column,
+	// sorted alphabetically for deterministic codegen output, used to
+	// emit the matching Go constants.
+	EnumValues []string
+	// EnumConsts holds the rendered (name, value) pairs for EnumValues,
+	// precomputed so the template doesn't need a helper func per value.
+	EnumConsts []EnumConst
+}
+
+// EnumConst is one generated `const` entry backing an enum field.
+type EnumConst struct {
+	Name  string
+	Value string
+}
+
+// NewFieldInfo builds the FieldInfo for a single column of a table, choosing
+// the Go type and tag the same way mapper.NewInfo expects to find them.
+func NewFieldInfo(goTableName, column string, schema *ovsdb.ColumnSchema) (*FieldInfo, error) {
+	fieldName := FieldName(column)
+	goType, enumType, enumValues, err := FieldType(goTableName, fieldName, schema)
+	if err != nil {
+		return nil, fmt.Errorf("column %s: %w", column, err)
+	}
+	var enumConsts []EnumConst
+	for _, v := range enumValues {
+		enumConsts = append(enumConsts, EnumConst{Name: EnumConstName(enumType, v), Value: v})
+	}
+	return &FieldInfo{
+		Column:     column,
+		FieldName:  fieldName,
+		Type:       goType,
+		Tag:        fmt.Sprintf("`ovsdb:\"%s\"`", column),
+		EnumType:   enumType,
+		EnumValues: enumValues,
+		EnumConsts: enumConsts,
+	}, nil
+}
+
+// FieldName converts an OVSDB column name (snake_case, possibly prefixed
+// with an underscore such as "_uuid") into an exported Go identifier.
+func FieldName(column string) string {
+	parts := strings.Split(strings.TrimPrefix(column, "_"), "_")
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		switch p {
+		case "uuid":
+			b.WriteString("UUID")
+		case "id", "ids":
+			b.WriteString(strings.ToUpper(p))
+		default:
+			b.WriteString(strings.ToUpper(p[:1]))
+			b.WriteString(p[1:])
+		}
+	}
+	return b.String()
+}
+
+// FieldType returns the Go type to use for a column, the name of the enum
+// type to generate (if any) and its declared values.
+//
+// The mapping mirrors ovsdb.NativeType: sets become slices, maps stay maps,
+// optional (min 0, max 1) atomic columns become pointers, and string columns
+// with an enumeration become a named string type with generated constants.
+func FieldType(goTableName, fieldName string, column *ovsdb.ColumnSchema) (string, string, []string, error) {
+	ct := column.TypeObj
+	if ct == nil {
+		// Atomic column, e.g. {"type": "string"}
+		goType := basicGoType(column.Type)
+		if goType == "" {
+			return "", "", nil, fmt.Errorf("unsupported atomic type %q", column.Type)
+		}
+		return goType, "", nil, nil
+	}
+
+	if ct.Value != nil {
+		// {"key": ..., "value": ...} -> map[K]V
+		keyType, _, _, err := baseType(ct.Key)
+		if err != nil {
+			return "", "", nil, err
+		}
+		valType, _, _, err := baseType(ct.Value)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return fmt.Sprintf("map[%s]%s", keyType, valType), "", nil, nil
+	}
+
+	elemType, enumType, enumValues := "", "", []string(nil)
+	if ct.Key.Enum != nil && ct.Key.Type == "string" {
+		enumType = goTableName + fieldName
+		enumValues = sortedEnumValues(ct.Key.Enum)
+		elemType = enumType
+	} else {
+		var err error
+		elemType, _, _, err = baseType(ct.Key)
+		if err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	if ct.Max() == 1 && ct.Min() == 1 {
+		return elemType, enumType, enumValues, nil
+	}
+	if ct.Max() == 1 && ct.Min() == 0 {
+		return "*" + elemType, enumType, enumValues, nil
+	}
+	// set with max > 1 (or unlimited) -> slice
+	return "[]" + elemType, enumType, enumValues, nil
+}
+
+func baseType(bt *ovsdb.BaseType) (string, string, []string, error) {
+	if bt.Enum != nil && bt.Type == "string" {
+		return "", "", nil, fmt.Errorf("enum base type requires a field name to generate a named type")
+	}
+	goType := basicGoType(bt.Type)
+	if goType == "" {
+		return "", "", nil, fmt.Errorf("unsupported base type %q", bt.Type)
+	}
+	return goType, "", nil, nil
+}
+
+func basicGoType(ovsType string) string {
+	switch ovsType {
+	case "string":
+		return "string"
+	case "integer":
+		return "int"
+	case "real":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "uuid":
+		return "string"
+	default:
+		return ""
+	}
+}
+
+func sortedEnumValues(enum []interface{}) []string {
+	values := make([]string, 0, len(enum))
+	for _, v := range enum {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// EnumConstName renders the Go constant name for one value of a generated
+// enum type, e.g. EnumConstName("LogicalSwitchPortType", "router") ->
+// "LogicalSwitchPortTypeRouter".
+func EnumConstName(enumType, value string) string {
+	var b strings.Builder
+	b.WriteString(enumType)
+	for _, p := range strings.Split(value, "-") {
+		if p == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]))
+		b.WriteString(p[1:])
+	}
+	return b.String()
+}
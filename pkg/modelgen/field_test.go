@@ -0,0 +1,113 @@
+package modelgen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func columnFromJSON(t *testing.T, j string) *ovsdb.ColumnSchema {
+	t.Helper()
+	var schema ovsdb.ColumnSchema
+	require.NoError(t, json.Unmarshal([]byte(j), &schema))
+	return &schema
+}
+
+func TestFieldName(t *testing.T) {
+	tests := []struct {
+		column string
+		want   string
+	}{
+		{"_uuid", "UUID"},
+		{"name", "Name"},
+		{"external_ids", "ExternalIDS"},
+		{"other_config", "OtherConfig"},
+		{"id", "ID"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FieldName(tt.column), tt.column)
+	}
+}
+
+func TestFieldTypeAtomic(t *testing.T) {
+	tests := []struct {
+		jsonType string
+		want     string
+	}{
+		{`{"type":"string"}`, "string"},
+		{`{"type":"integer"}`, "int"},
+		{`{"type":"real"}`, "float64"},
+		{`{"type":"boolean"}`, "bool"},
+		{`{"type":"uuid"}`, "string"},
+	}
+	for _, tt := range tests {
+		column := columnFromJSON(t, tt.jsonType)
+		goType, enumType, enumValues, err := FieldType("Table", "Field", column)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, goType)
+		assert.Empty(t, enumType)
+		assert.Empty(t, enumValues)
+	}
+}
+
+func TestFieldTypeUnsupportedAtomic(t *testing.T) {
+	column := &ovsdb.ColumnSchema{Type: "bogus"}
+	_, _, _, err := FieldType("Table", "Field", column)
+	require.Error(t, err)
+}
+
+func TestFieldTypeOptional(t *testing.T) {
+	column := columnFromJSON(t, `{"type":{"key":"string","min":0,"max":1}}`)
+	goType, _, _, err := FieldType("Table", "Field", column)
+	require.NoError(t, err)
+	assert.Equal(t, "*string", goType)
+}
+
+func TestFieldTypeSet(t *testing.T) {
+	column := columnFromJSON(t, `{"type":{"key":"string","min":0,"max":"unlimited"}}`)
+	goType, _, _, err := FieldType("Table", "Field", column)
+	require.NoError(t, err)
+	assert.Equal(t, "[]string", goType)
+}
+
+func TestFieldTypeMap(t *testing.T) {
+	column := columnFromJSON(t, `{"type":{"key":"string","value":"string"}}`)
+	goType, _, _, err := FieldType("Table", "Field", column)
+	require.NoError(t, err)
+	assert.Equal(t, "map[string]string", goType)
+}
+
+func TestFieldTypeEnum(t *testing.T) {
+	column := columnFromJSON(t, `{"type":{"key":{"type":"string","enum":["set",["router","access"]]}}}`)
+	goType, enumType, enumValues, err := FieldType("LogicalSwitchPort", "Type", column)
+	require.NoError(t, err)
+	assert.Equal(t, "LogicalSwitchPortType", goType)
+	assert.Equal(t, "LogicalSwitchPortType", enumType)
+	assert.Equal(t, []string{"access", "router"}, enumValues)
+}
+
+func TestNewFieldInfoEnum(t *testing.T) {
+	column := columnFromJSON(t, `{"type":{"key":{"type":"string","enum":["set",["router","access"]]}}}`)
+	field, err := NewFieldInfo("LogicalSwitchPort", "type", column)
+	require.NoError(t, err)
+	assert.Equal(t, "Type", field.FieldName)
+	assert.Equal(t, "LogicalSwitchPortType", field.Type)
+	assert.Equal(t, "`ovsdb:\"type\"`", field.Tag)
+	require.Len(t, field.EnumConsts, 2)
+	assert.Equal(t, EnumConst{Name: "LogicalSwitchPortTypeAccess", Value: "access"}, field.EnumConsts[0])
+	assert.Equal(t, EnumConst{Name: "LogicalSwitchPortTypeRouter", Value: "router"}, field.EnumConsts[1])
+}
+
+func TestNewFieldInfoUnsupportedType(t *testing.T) {
+	column := &ovsdb.ColumnSchema{Type: "bogus"}
+	_, err := NewFieldInfo("Table", "weird", column)
+	require.Error(t, err)
+}
+
+func TestEnumConstName(t *testing.T) {
+	assert.Equal(t, "LogicalSwitchPortTypeRouter", EnumConstName("LogicalSwitchPortType", "router"))
+	assert.Equal(t, "LogicalSwitchPortTypeRouterPort", EnumConstName("LogicalSwitchPortType", "router-port"))
+}
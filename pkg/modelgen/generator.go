@@ -0,0 +1,104 @@
+// Package modelgen generates typed Go model structs from an OVSDB schema,
+// so that callers of mapper.NewInfo no longer have to hand-write and
+// hand-maintain structs whose `ovsdb` tags match a schema.
+package modelgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/template"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+)
+
+// Generator renders one Go file per table of a DatabaseSchema, plus a
+// shared file exposing the parsed schema via a package-level Schema().
+type Generator struct {
+	// PackageName is the package the generated files belong to
+	PackageName string
+}
+
+// NewGenerator returns a Generator that emits files into package pkg.
+func NewGenerator(pkg string) *Generator {
+	return &Generator{PackageName: pkg}
+}
+
+// Generate parses schemaJSON and writes one model_<table>.go file per table
+// (table name lowercased) plus a schema.go file into destDir.
+func (g *Generator) Generate(schemaJSON []byte, destDir string) error {
+	var schema ovsdb.DatabaseSchema
+	if err := json.Unmarshal(schemaJSON, &schema); err != nil {
+		return fmt.Errorf("parsing schema: %w", err)
+	}
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", destDir, err)
+	}
+
+	tableNames := make([]string, 0, len(schema.Tables))
+	for name := range schema.Tables {
+		tableNames = append(tableNames, name)
+	}
+	sort.Strings(tableNames)
+
+	for _, name := range tableNames {
+		table := schema.Tables[name]
+		info, err := NewTableInfo(g.PackageName, name, &table)
+		if err != nil {
+			return err
+		}
+		if err := g.writeFile(destDir, fileName(name), modelTemplate, info); err != nil {
+			return err
+		}
+	}
+
+	schemaFile := struct {
+		PackageName string
+		// SchemaJSON is the Go-quoted form of schemaJSON, safe to splice
+		// directly into the template as a string literal regardless of
+		// what characters (including backticks) the schema itself contains.
+		SchemaJSON string
+	}{
+		PackageName: g.PackageName,
+		SchemaJSON:  strconv.Quote(string(schemaJSON)),
+	}
+	return g.writeFile(destDir, "schema.go", schemaTemplate, schemaFile)
+}
+
+func (g *Generator) writeFile(destDir, name string, tmpl *template.Template, data interface{}) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("rendering %s: %w", name, err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting %s: %w", name, err)
+	}
+	return os.WriteFile(filepath.Join(destDir, name), formatted, 0o644)
+}
+
+func fileName(tableName string) string {
+	return "model_" + toSnakeFileName(tableName) + ".go"
+}
+
+func toSnakeFileName(tableName string) string {
+	out := make([]byte, 0, len(tableName))
+	for i := 0; i < len(tableName); i++ {
+		c := tableName[i]
+		if c == '_' {
+			out = append(out, '_')
+			continue
+		}
+		if c >= 'A' && c <= 'Z' {
+			c = c - 'A' + 'a'
+		}
+		out = append(out, c)
+	}
+	return string(out)
+}
@@ -0,0 +1,69 @@
+package modelgen
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchemaJSON = `{
+	"name": "TestSchema",
+	"version": "0.0.1",
+	"tables": {
+		"Logical_Switch": {
+			"columns": {
+				"name": {"type": "string"},
+				"external_ids": {"type": {"key": "string", "value": "string"}},
+				"ports": {"type": {"key": {"type": "uuid"}, "min": 0, "max": "unlimited"}}
+			}
+		},
+		"Logical_Switch_Port": {
+			"columns": {
+				"name": {"type": "string"},
+				"type": {"type": {"key": {"type": "string", "enum": ["set", ["router", "access"]]}}},
+				"tag": {"type": {"key": "integer", "min": 0, "max": 1}}
+			}
+		}
+	}
+}`
+
+// TestGenerateProducesParsableSource renders every file for testSchemaJSON
+// and parses each one with go/parser, confirming the template output is not
+// just gofmt-clean but syntactically valid Go.
+func TestGenerateProducesParsableSource(t *testing.T) {
+	destDir := t.TempDir()
+	g := NewGenerator("testmodel")
+	require.NoError(t, g.Generate([]byte(testSchemaJSON), destDir))
+
+	entries, err := os.ReadDir(destDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		path := filepath.Join(destDir, entry.Name())
+		src, err := os.ReadFile(path)
+		require.NoError(t, err)
+		_, err = parser.ParseFile(fset, path, src, parser.AllErrors)
+		assert.NoError(t, err, "generated file %s did not parse as valid Go", entry.Name())
+	}
+
+	assert.FileExists(t, filepath.Join(destDir, "model_logical_switch.go"))
+	assert.FileExists(t, filepath.Join(destDir, "model_logical_switch_port.go"))
+	assert.FileExists(t, filepath.Join(destDir, "schema.go"))
+}
+
+func TestGenerateInvalidSchema(t *testing.T) {
+	g := NewGenerator("testmodel")
+	err := g.Generate([]byte("not json"), t.TempDir())
+	require.Error(t, err)
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "model_logical_switch_port.go", fileName("Logical_Switch_Port"))
+}
@@ -0,0 +1,64 @@
+package modelgen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+)
+
+// TableInfo holds everything needed to render a single generated model file
+// for one table of a DatabaseSchema.
+type TableInfo struct {
+	// TableName is the OVSDB table name (e.g. "Logical_Switch")
+	TableName string
+	// StructName is the exported Go type name for the table (e.g. "LogicalSwitch")
+	StructName string
+	// PackageName is the Go package the generated file belongs to
+	PackageName string
+	// Fields are the generated struct fields, sorted by column name with
+	// "_uuid" always first to match the layout hand-written models use.
+	Fields []*FieldInfo
+}
+
+// NewTableInfo builds the TableInfo used to render the model for tableName.
+func NewTableInfo(packageName, tableName string, table *ovsdb.TableSchema) (*TableInfo, error) {
+	columns := make([]string, 0, len(table.Columns)+1)
+	columns = append(columns, "_uuid")
+	for column := range table.Columns {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns[1:])
+
+	structName := StructName(tableName)
+	fields := make([]*FieldInfo, 0, len(columns))
+	for _, column := range columns {
+		var schema *ovsdb.ColumnSchema
+		if column == "_uuid" {
+			schema = &ovsdb.ColumnSchema{Type: "uuid"}
+		} else {
+			schema = table.Column(column)
+			if schema == nil {
+				return nil, fmt.Errorf("table %s: column %s not found in schema", tableName, column)
+			}
+		}
+		field, err := NewFieldInfo(structName, column, schema)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", tableName, err)
+		}
+		fields = append(fields, field)
+	}
+
+	return &TableInfo{
+		TableName:   tableName,
+		StructName:  structName,
+		PackageName: packageName,
+		Fields:      fields,
+	}, nil
+}
+
+// StructName converts an OVSDB table name into an exported Go type name,
+// e.g. "Logical_Switch_Port" -> "LogicalSwitchPort".
+func StructName(tableName string) string {
+	return FieldName(tableName)
+}
@@ -0,0 +1,46 @@
+package modelgen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructName(t *testing.T) {
+	assert.Equal(t, "LogicalSwitchPort", StructName("Logical_Switch_Port"))
+}
+
+func TestNewTableInfo(t *testing.T) {
+	var table ovsdb.TableSchema
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"columns": {
+			"name": {"type": "string"},
+			"external_ids": {"type": {"key": "string", "value": "string"}}
+		}
+	}`), &table))
+
+	info, err := NewTableInfo("ovnnb", "Logical_Switch", &table)
+	require.NoError(t, err)
+	assert.Equal(t, "Logical_Switch", info.TableName)
+	assert.Equal(t, "LogicalSwitch", info.StructName)
+	assert.Equal(t, "ovnnb", info.PackageName)
+
+	// _uuid always comes first, the rest sorted alphabetically.
+	require.Len(t, info.Fields, 3)
+	assert.Equal(t, "_uuid", info.Fields[0].Column)
+	assert.Equal(t, "external_ids", info.Fields[1].Column)
+	assert.Equal(t, "name", info.Fields[2].Column)
+}
+
+func TestNewTableInfoUnsupportedColumnType(t *testing.T) {
+	table := &ovsdb.TableSchema{
+		Columns: map[string]*ovsdb.ColumnSchema{
+			"name": {Type: "bogus"},
+		},
+	}
+	_, err := NewTableInfo("ovnnb", "Logical_Switch", table)
+	require.Error(t, err)
+}
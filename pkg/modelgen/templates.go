@@ -0,0 +1,76 @@
+package modelgen
+
+import "text/template"
+
+// modelTemplate renders the Go source for a single table's model file.
+var modelTemplate = template.Must(template.New("model").Parse(`// Code generated by libovsdb modelgen. DO NOT EDIT.
+
+package {{ .PackageName }}
+
+import (
+	"github.com/ovn-org/libovsdb/mapper"
+)
+
+{{ range .Fields }}{{ if .EnumType }}{{ $enumType := .EnumType }}// {{ $enumType }} is the enumeration of values the {{ .Column }} column accepts
+type {{ $enumType }} string
+
+const (
+{{- range .EnumConsts }}
+	{{ .Name }} {{ $enumType }} = "{{ .Value }}"
+{{- end }}
+)
+{{ end }}{{ end }}
+// {{ .StructName }} is a generated model for the {{ .TableName }} table
+type {{ .StructName }} struct {
+{{- range .Fields }}
+	{{ .FieldName }} {{ .Type }} {{ .Tag }}
+{{- end }}
+}
+
+// TableName returns the OVSDB table this model corresponds to
+func (a *{{ .StructName }}) TableName() string {
+	return "{{ .TableName }}"
+}
+
+// {{ .StructName }}Indexes returns the set of schema indexes for which obj
+// currently holds non-default values, suitable for building Get/List
+// conditions without hand-rolling index validation.
+func {{ .StructName }}Indexes(obj *{{ .StructName }}) ([][]string, error) {
+	table := Schema().Table("{{ .TableName }}")
+	info, err := mapper.NewInfo(table, obj)
+	if err != nil {
+		return nil, err
+	}
+	return info.Indexes()
+}
+`))
+
+// schemaTemplate renders the shared file emitted once per generated package,
+// holding the parsed schema every table file's Indexes helper relies on.
+var schemaTemplate = template.Must(template.New("schema").Parse(`// Code generated by libovsdb modelgen. DO NOT EDIT.
+
+package {{ .PackageName }}
+
+import (
+	"encoding/json"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+)
+
+const schemaJSON = {{ .SchemaJSON }}
+
+var parsedSchema *ovsdb.DatabaseSchema
+
+// Schema returns the parsed OVSDB schema this package's models were
+// generated from.
+func Schema() *ovsdb.DatabaseSchema {
+	if parsedSchema == nil {
+		var schema ovsdb.DatabaseSchema
+		if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
+			panic(err)
+		}
+		parsedSchema = &schema
+	}
+	return parsedSchema
+}
+`))